@@ -0,0 +1,190 @@
+package honeybadger
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultFingerprintFrames bounds how many (normalized, line-number-free)
+// stack frames the default Fingerprint hashes. Frames beyond this depth
+// rarely distinguish one crash site from another and would otherwise stop
+// recursive panics at different depths from collapsing together.
+const defaultFingerprintFrames = 5
+
+// Fingerprint computes a dedup key for an Error. Configuration.Fingerprint
+// may be set to override fingerprintError, the default. It's computed from
+// the Error rather than the eventual Notice because the whole point of the
+// aggregator is to decide whether a Notice needs building at all — building
+// one just to fingerprint it would defeat that.
+type Fingerprint func(Error) string
+
+// fingerprintError is the default fingerprint: a hash of the error's class
+// and the method name of its top stack frames, deliberately ignoring line
+// numbers and the "(xN)" repetition count DefaultStackFilter appends, so the
+// same recursive panic at a different recursion depth still collapses to one
+// fingerprint.
+func fingerprintError(hbErr Error) string {
+	h := sha1.New()
+	fmt.Fprint(h, hbErr.Class)
+
+	frames := hbErr.Stack
+	if len(frames) > defaultFingerprintFrames {
+		frames = frames[:defaultFingerprintFrames]
+	}
+	for _, frame := range frames {
+		method := frame.Method
+		if idx := strings.LastIndex(method, " (x"); idx >= 0 {
+			method = method[:idx]
+		}
+		fmt.Fprint(h, method)
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// aggregatedEntry tracks one fingerprint's occurrences within the current
+// dedup window. No Notice is built until the window closes: occurrence_count,
+// first_seen, and last_seen are only known then, and passing them to
+// buildNotice as an ordinary Context extra (the same path Params/CGIData/
+// trace IDs already go through) is the only way to guarantee they reach the
+// Notice actually delivered, rather than hoping a mutation after the fact is
+// visible through it.
+type aggregatedEntry struct {
+	ctx       context.Context
+	hbErr     Error
+	extra     []interface{}
+	token     string
+	count     int
+	firstSeen time.Time
+	lastSeen  time.Time
+}
+
+// aggregator collapses occurrences that share a fingerprint into a single
+// Notice delivered once per Configuration.DedupWindow (or sooner, if
+// Client.Flush is called first).
+type aggregator struct {
+	window      time.Duration
+	buildNotice func(hbErr Error, extra ...interface{}) (*Notice, error)
+	deliver     func(ctx context.Context, hbErr Error, notice *Notice) error
+
+	mu      sync.Mutex
+	pending map[string]*aggregatedEntry
+}
+
+func newAggregator(
+	window time.Duration,
+	buildNotice func(hbErr Error, extra ...interface{}) (*Notice, error),
+	deliver func(ctx context.Context, hbErr Error, notice *Notice) error,
+) *aggregator {
+	return &aggregator{
+		window:      window,
+		buildNotice: buildNotice,
+		deliver:     deliver,
+		pending:     make(map[string]*aggregatedEntry),
+	}
+}
+
+// add records one occurrence of fingerprint and returns a token the caller
+// can hand back to Notify's caller immediately. The first call for a given
+// fingerprint within a window schedules its delivery; later calls just
+// update the running count and last-seen time and reuse its token, since
+// only one Notice is ever actually sent per window.
+func (a *aggregator) add(ctx context.Context, fingerprint string, hbErr Error, extra ...interface{}) string {
+	now := time.Now()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if entry, ok := a.pending[fingerprint]; ok {
+		entry.count++
+		entry.lastSeen = now
+		return entry.token
+	}
+
+	entry := &aggregatedEntry{
+		// ctx is held for up to a.window (by design, tens of seconds) before
+		// deliverEntry uses it, by which point a request/RPC-scoped ctx is
+		// essentially always already Done. Detach so client.deliver's
+		// ctx.Err() check doesn't drop every aggregated notice.
+		ctx:       context.WithoutCancel(ctx),
+		hbErr:     hbErr,
+		extra:     extra,
+		token:     newToken(),
+		count:     1,
+		firstSeen: now,
+		lastSeen:  now,
+	}
+	a.pending[fingerprint] = entry
+
+	time.AfterFunc(a.window, func() { a.flush(fingerprint) })
+
+	return entry.token
+}
+
+// flush delivers the aggregated entry for fingerprint, if it hasn't already
+// been delivered by a call to flushAll.
+func (a *aggregator) flush(fingerprint string) {
+	a.mu.Lock()
+	entry, ok := a.pending[fingerprint]
+	if ok {
+		delete(a.pending, fingerprint)
+	}
+	a.mu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	a.deliverEntry(entry)
+}
+
+// flushAll delivers every entry still waiting out its window, e.g. when
+// Client.Flush is called before the window would otherwise close.
+func (a *aggregator) flushAll() {
+	a.mu.Lock()
+	entries := make([]*aggregatedEntry, 0, len(a.pending))
+	for fingerprint, entry := range a.pending {
+		entries = append(entries, entry)
+		delete(a.pending, fingerprint)
+	}
+	a.mu.Unlock()
+
+	for _, entry := range entries {
+		a.deliverEntry(entry)
+	}
+}
+
+func (a *aggregator) deliverEntry(entry *aggregatedEntry) {
+	extra := append(entry.extra, Context{
+		"occurrence_count": entry.count,
+		"first_seen":       entry.firstSeen,
+		"last_seen":        entry.lastSeen,
+	})
+
+	notice, err := a.buildNotice(entry.hbErr, extra...)
+	if err != nil {
+		return
+	}
+
+	a.deliver(entry.ctx, entry.hbErr, notice)
+}
+
+// newToken generates a v4-ish UUID for aggregated occurrences that haven't
+// built (and so don't yet have) a real Notice token. Falling back to a
+// timestamp keeps this infallible; a low-entropy token here only risks
+// miscorrelating a log line, never a lost notice.
+func newToken() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,90 @@
+package honeybadger
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAggregatorCollapsesDuplicatesIntoOneDelivery(t *testing.T) {
+	var mu sync.Mutex
+	var delivered []*Notice
+
+	buildNotice := func(hbErr Error, extra ...interface{}) (*Notice, error) {
+		return &Notice{Token: "tok"}, nil
+	}
+	deliver := func(ctx context.Context, hbErr Error, notice *Notice) error {
+		mu.Lock()
+		delivered = append(delivered, notice)
+		mu.Unlock()
+		return nil
+	}
+
+	a := newAggregator(20*time.Millisecond, buildNotice, deliver)
+	hbErr := Error{Class: "boom"}
+
+	first := a.add(context.Background(), "fp", hbErr)
+	second := a.add(context.Background(), "fp", hbErr)
+
+	if first != second {
+		t.Fatalf("expected duplicate occurrences to share a token, got %q and %q", first, second)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(delivered) != 1 {
+		t.Fatalf("expected exactly 1 delivery for 2 occurrences within the window, got %d", len(delivered))
+	}
+}
+
+func TestAggregatorDeliversAfterCallerContextIsCancelled(t *testing.T) {
+	delivered := make(chan context.Context, 1)
+
+	buildNotice := func(hbErr Error, extra ...interface{}) (*Notice, error) {
+		return &Notice{Token: "tok"}, nil
+	}
+	deliver := func(ctx context.Context, hbErr Error, notice *Notice) error {
+		delivered <- ctx
+		return nil
+	}
+
+	a := newAggregator(20*time.Millisecond, buildNotice, deliver)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	a.add(ctx, "fp", Error{Class: "boom"})
+	cancel()
+
+	select {
+	case deliverCtx := <-delivered:
+		if err := deliverCtx.Err(); err != nil {
+			t.Fatalf("deliver received a context that was already Done: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("aggregator did not deliver the pending entry")
+	}
+}
+
+func TestAggregatorFlushAllDeliversImmediately(t *testing.T) {
+	delivered := make(chan struct{}, 1)
+
+	buildNotice := func(hbErr Error, extra ...interface{}) (*Notice, error) {
+		return &Notice{Token: "tok"}, nil
+	}
+	deliver := func(ctx context.Context, hbErr Error, notice *Notice) error {
+		delivered <- struct{}{}
+		return nil
+	}
+
+	a := newAggregator(time.Hour, buildNotice, deliver)
+	a.add(context.Background(), "fp", Error{Class: "boom"})
+	a.flushAll()
+
+	select {
+	case <-delivered:
+	case <-time.After(time.Second):
+		t.Fatal("flushAll did not deliver the pending entry")
+	}
+}
@@ -0,0 +1,163 @@
+package honeybadger
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	pb "github.com/timmyhoa/honeybadger-go/proto"
+)
+
+// grpcBackoffMax caps the exponential reconnect delay for the gRPC stream.
+const grpcBackoffMax = 30 * time.Second
+
+// grpcBackend delivers notices over a single, persistent bi-directional
+// stream instead of opening a new connection per notify. It reconnects with
+// exponential backoff and applies any Hint the server pushes back (sampling
+// or back-off) to subsequent sends.
+type grpcBackend struct {
+	target string
+	config *Configuration
+
+	mu     sync.Mutex
+	conn   *grpc.ClientConn
+	client pb.NoticeServiceClient
+	stream pb.NoticeService_StreamClient
+
+	sampleRate float64
+	backoffTil time.Time
+}
+
+func newGRPCBackend(config *Configuration) *grpcBackend {
+	return &grpcBackend{
+		target:     config.Endpoint,
+		config:     config,
+		sampleRate: 1,
+	}
+}
+
+func (backend *grpcBackend) Notify(feature Feature, payload Payload) error {
+	return backend.NotifyContext(context.Background(), feature, payload)
+}
+
+// NotifyContext sends payload over the persistent stream, (re)dialing it if
+// necessary, and honors any outstanding back-off Hint from the server.
+func (backend *grpcBackend) NotifyContext(ctx context.Context, feature Feature, payload Payload) error {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+
+	if time.Now().Before(backend.backoffTil) {
+		return nil
+	}
+
+	// sampleRate < 1 means the server asked us to send only a fraction of
+	// notices (Hint_SAMPLE); a dropped notice isn't an error, so report
+	// success without ever dialing or sending.
+	if backend.sampleRate < 1 && rand.Float64() >= backend.sampleRate {
+		return nil
+	}
+
+	stream, err := backend.streamLocked(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := stream.Send(&pb.Notice{
+		Feature: string(feature),
+		Payload: payload.toJSON(),
+	}); err != nil {
+		backend.resetLocked()
+		return err
+	}
+
+	return nil
+}
+
+// streamLocked returns the current stream, dialing a new connection and
+// opening a new stream (with exponential backoff between attempts) if the
+// previous one is gone. Callers must hold backend.mu.
+func (backend *grpcBackend) streamLocked(ctx context.Context) (pb.NoticeService_StreamClient, error) {
+	if backend.stream != nil {
+		return backend.stream, nil
+	}
+
+	creds := backend.config.GRPCCredentials
+	if creds == nil {
+		creds = insecure.NewCredentials()
+	}
+
+	var lastErr error
+	for attempt, delay := 0, 250*time.Millisecond; attempt < 10; attempt++ {
+		conn, err := grpc.DialContext(ctx, backend.target, grpc.WithBlock(), grpc.WithTransportCredentials(creds))
+		if err != nil {
+			lastErr = err
+			time.Sleep(delay)
+			if delay *= 2; delay > grpcBackoffMax {
+				delay = grpcBackoffMax
+			}
+			continue
+		}
+
+		client := pb.NewNoticeServiceClient(conn)
+		stream, err := client.Stream(ctx)
+		if err != nil {
+			conn.Close()
+			lastErr = err
+			time.Sleep(delay)
+			if delay *= 2; delay > grpcBackoffMax {
+				delay = grpcBackoffMax
+			}
+			continue
+		}
+
+		backend.conn = conn
+		backend.client = client
+		backend.stream = stream
+		go backend.readHints(stream)
+
+		return stream, nil
+	}
+
+	return nil, lastErr
+}
+
+// readHints applies sampling/back-off hints pushed by the server until the
+// stream closes, at which point it tears the stream down so the next notify
+// reconnects.
+func (backend *grpcBackend) readHints(stream pb.NoticeService_StreamClient) {
+	for {
+		hint, err := stream.Recv()
+		if err != nil {
+			backend.mu.Lock()
+			if backend.stream == stream {
+				backend.resetLocked()
+			}
+			backend.mu.Unlock()
+			return
+		}
+
+		backend.mu.Lock()
+		switch hint.Action {
+		case pb.Hint_SAMPLE:
+			backend.sampleRate = hint.SampleRate
+		case pb.Hint_BACK_OFF:
+			backend.backoffTil = time.Now().Add(time.Duration(hint.RetryAfterMs) * time.Millisecond)
+		}
+		backend.mu.Unlock()
+	}
+}
+
+// resetLocked drops the current connection/stream so the next notify opens
+// a fresh one. Callers must hold backend.mu.
+func (backend *grpcBackend) resetLocked() {
+	if backend.conn != nil {
+		backend.conn.Close()
+	}
+	backend.conn = nil
+	backend.client = nil
+	backend.stream = nil
+}
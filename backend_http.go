@@ -0,0 +1,71 @@
+package honeybadger
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// httpBackend is the default Backend implementation. It POSTs each Notice to
+// the configured Honeybadger endpoint, establishing a new connection (or
+// reusing one from http.Client's pool) for every notify.
+type httpBackend struct {
+	URL    string
+	APIKey string
+	Client *http.Client
+}
+
+func newHTTPBackend(config *Configuration) *httpBackend {
+	return &httpBackend{
+		URL:    fmt.Sprintf("https://%s", config.Endpoint),
+		APIKey: config.APIKey,
+		Client: &http.Client{},
+	}
+}
+
+func (backend *httpBackend) Notify(feature Feature, payload Payload) error {
+	return backend.NotifyContext(context.Background(), feature, payload)
+}
+
+// NotifyContext behaves like Notify, but the request is built with ctx so a
+// cancelled or expired context aborts the in-flight POST instead of letting
+// it run to completion.
+func (backend *httpBackend) NotifyContext(ctx context.Context, feature Feature, payload Payload) error {
+	buf := getBuffer()
+	defer putBuffer(buf)
+	buf.Write(payload.toJSON())
+
+	req, err := backend.newRequest(ctx, feature, buf)
+	if err != nil {
+		return err
+	}
+	return backend.do(req)
+}
+
+func (backend *httpBackend) newRequest(ctx context.Context, feature Feature, body *bytes.Buffer) (*http.Request, error) {
+	url := fmt.Sprintf("%s/v1/%s", backend.URL, feature)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-Key", backend.APIKey)
+	return req, nil
+}
+
+func (backend *httpBackend) do(req *http.Request) error {
+	res, err := backend.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated {
+		body, _ := ioutil.ReadAll(res.Body)
+		return fmt.Errorf("bad response status: %d body: %s", res.StatusCode, body)
+	}
+
+	return nil
+}
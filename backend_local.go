@@ -0,0 +1,41 @@
+package honeybadger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+)
+
+// localBackend writes notices to a local writer instead of making any
+// network call, so it can record a Notice's JSON payload for offline
+// debugging without talking to Honeybadger at all. It writes to
+// Configuration.LocalPath if set, appending and creating the file as
+// needed, or to stdout otherwise.
+type localBackend struct {
+	Writer io.Writer
+}
+
+func newLocalBackend(config *Configuration) *localBackend {
+	if config.LocalPath == "" {
+		return &localBackend{Writer: os.Stdout}
+	}
+
+	f, err := os.OpenFile(config.LocalPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		config.Logger.Printf("honeybadger: failed to open local backend file %q, falling back to stdout: %v\n", config.LocalPath, err)
+		return &localBackend{Writer: os.Stdout}
+	}
+	return &localBackend{Writer: f}
+}
+
+func (backend *localBackend) Notify(feature Feature, payload Payload) error {
+	_, err := fmt.Fprintf(backend.Writer, "[honeybadger] %s: %s\n", feature, payload.toJSON())
+	return err
+}
+
+// NotifyContext ignores ctx; writing to a local io.Writer has nothing to
+// cancel. It exists so localBackend satisfies ContextBackend.
+func (backend *localBackend) NotifyContext(ctx context.Context, feature Feature, payload Payload) error {
+	return backend.Notify(feature, payload)
+}
@@ -1,6 +1,7 @@
 package honeybadger
 
 import (
+	"context"
 	"net/http"
 	"strings"
 
@@ -19,6 +20,16 @@ type Backend interface {
 	Notify(feature Feature, payload Payload) error
 }
 
+// ContextBackend is an optional extension of Backend. A Backend that
+// implements it receives the context.Context passed to NotifyContext, so it
+// can propagate cancellation and deadlines down to the outbound call instead
+// of always running to completion. Backends that don't implement it fall
+// back to Backend.Notify; calls made through the context-less Notify still
+// reach NotifyContext, with context.Background().
+type ContextBackend interface {
+	NotifyContext(ctx context.Context, feature Feature, payload Payload) error
+}
+
 type noticeHandler func(*Notice) error
 
 // Client is the manager for interacting with the Honeybadger service. It holds
@@ -28,6 +39,7 @@ type Client struct {
 	context              *contextSync
 	worker               worker
 	beforeNotifyHandlers []noticeHandler
+	aggregator           *aggregator
 }
 
 // Configure updates the client configuration with the supplied config.
@@ -40,8 +52,13 @@ func (client *Client) SetContext(context Context) {
 	client.context.Update(context)
 }
 
-// Flush blocks until the worker has processed its queue.
+// Flush blocks until the worker has processed its queue. If DedupWindow is
+// configured, any notices still waiting out their dedup window are
+// delivered first.
 func (client *Client) Flush() {
+	if client.aggregator != nil {
+		client.aggregator.flushAll()
+	}
 	client.worker.Flush()
 }
 
@@ -54,38 +71,122 @@ func (client *Client) BeforeNotify(handler func(notice *Notice) error) {
 
 // Notify reports the error err to the Honeybadger service.
 func (client *Client) Notify(err interface{}, extra ...interface{}) (string, error) {
+	return client.notify(context.Background(), client.newError(err, 2), extra...)
+}
+
+// NotifyContext reports the error err to the Honeybadger service, threading
+// ctx through to the worker and the configured Backend so request deadlines,
+// cancellation, and trace IDs propagate all the way to the outbound call. If
+// client.Config.ExtractTrace is set, a trace/span ID is read from ctx (see
+// traceFromContext) and attached to the notice's context automatically.
+func (client *Client) NotifyContext(ctx context.Context, err interface{}, extra ...interface{}) (string, error) {
+	if client.Config.ExtractTrace {
+		if trace, ok := traceFromContext(ctx); ok {
+			extra = append(extra, trace)
+		}
+	}
+	return client.notify(ctx, client.newError(err, 2), extra...)
+}
+
+// newError builds an Error for thing, capturing up to client.Config.MaxFrames
+// stack frames, and runs it through the configured StackFilter (or
+// DefaultStackFilter, if none is set) before it's attached to a Notice.
+func (client *Client) newError(thing interface{}, stackOffset int) Error {
+	maxFrames := client.Config.MaxFrames
+	if maxFrames <= 0 {
+		maxFrames = defaultMaxFrames
+	}
+	hbErr := newError(thing, stackOffset+1, maxFrames)
+
+	filter := client.Config.StackFilter
+	if filter == nil {
+		filter = DefaultStackFilter
+	}
+	hbErr.Stack = filter(hbErr.Stack)
+
+	return hbErr
+}
+
+func (client *Client) notify(ctx context.Context, hbErr Error, extra ...interface{}) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	if client.aggregator != nil {
+		// The Notice isn't built until the dedup window closes (see
+		// aggregator.deliverEntry), so occurrence_count/first_seen/last_seen
+		// can be merged in as an ordinary extra at that point instead of
+		// mutating a Notice that's already been handed to buildNotice.
+		fingerprint := fingerprintError(hbErr)
+		if client.Config.Fingerprint != nil {
+			fingerprint = client.Config.Fingerprint(hbErr)
+		}
+		return client.aggregator.add(ctx, fingerprint, hbErr, extra...), nil
+	}
+
+	notice, err := client.buildNotice(hbErr, extra...)
+	if err != nil {
+		return "", err
+	}
+
+	return notice.Token, client.deliver(ctx, hbErr, notice)
+}
+
+// buildNotice merges hbErr and extra into a Notice and runs the
+// BeforeNotify chain. It performs no I/O, so it's safe to call well ahead of
+// the point a Notice is actually handed to a Backend.
+func (client *Client) buildNotice(hbErr Error, extra ...interface{}) (*Notice, error) {
 	extra = append([]interface{}{client.context.internal}, extra...)
-	notice := newNotice(client.Config, newError(err, 2), extra...)
+	notice := newNotice(client.Config, hbErr, extra...)
 	for _, handler := range client.beforeNotifyHandlers {
 		if err := handler(notice); err != nil {
-			return "", err
+			return nil, err
 		}
 	}
+	return notice, nil
+}
 
+// deliver hands notice to the configured Backend, synchronously or via the
+// worker depending on Configuration.Sync, and returns hbErr's pooled stack
+// frames and notice itself to their pools once the attempt completes.
+func (client *Client) deliver(ctx context.Context, hbErr Error, notice *Notice) error {
 	notifyFn := func() error {
+		defer releaseStack(hbErr)
+		defer putNotice(notice)
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if backend, ok := client.Config.Backend.(ContextBackend); ok {
+			return backend.NotifyContext(ctx, Notices, notice)
+		}
 		return client.Config.Backend.Notify(Notices, notice)
 	}
 
 	if client.Config.Sync {
 		if notifyErr := notifyFn(); notifyErr != nil {
 			client.Config.Logger.Printf("notify error: %v\n", notifyErr)
-			return "", notifyErr
-		}
-	} else {
-		if workerPushErr := client.worker.Push(notifyFn); workerPushErr != nil {
-			client.Config.Logger.Printf("worker error: %v\n", workerPushErr)
-			return "", workerPushErr
+			return notifyErr
 		}
+		return nil
 	}
 
-	return notice.Token, nil
+	if workerPushErr := client.worker.Push(notifyFn); workerPushErr != nil {
+		client.Config.Logger.Printf("worker error: %v\n", workerPushErr)
+		return workerPushErr
+	}
+	return nil
 }
 
 // Monitor automatically reports panics which occur in the function it's called
 // from. Must be deferred.
 func (client *Client) Monitor() {
 	if err := recover(); err != nil {
-		client.Notify(newError(err, 2))
+		if client.Config.CaptureGoroutines {
+			client.Notify(client.newError(err, 2), Context{"goroutines": captureGoroutineStacks(client.Config.StackFilter)})
+		} else {
+			client.Notify(client.newError(err, 2))
+		}
 		client.Flush()
 		panic(err)
 	}
@@ -100,7 +201,14 @@ func (client *Client) Handler(h http.Handler) http.Handler {
 	fn := func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
 			if err := recover(); err != nil {
-				client.Notify(newError(err, 2), Params(r.Form), getCGIData(r), *r.URL)
+				// r.Context() is cancelled the instant ServeHTTP returns, which
+				// happens right after this panic is re-raised below. Delivery is
+				// usually queued for the background worker, so notifying with
+				// r.Context() directly would have the worker observe an
+				// already-cancelled context and silently drop the notice.
+				// context.WithoutCancel keeps any values (trace IDs) NotifyContext
+				// extracts, but lets the notice outlive the request it came from.
+				client.NotifyContext(context.WithoutCancel(r.Context()), client.newError(err, 2), Params(r.Form), getCGIData(r), *r.URL)
 				panic(err)
 			}
 		}()
@@ -112,7 +220,10 @@ func (client *Client) Handler(h http.Handler) http.Handler {
 func (client *Client) GinRecoveryHandler() func(*gin.Context, interface{}) {
 	return func(ctx *gin.Context, err interface{}) {
 		r := ctx.Request
-		client.Notify(newError(err, 2), Params(r.Form), getCGIData(r), *r.URL)
+		// See the matching comment in Handler: detach from the request context
+		// before notifying so the worker doesn't see it cancelled once this
+		// handler returns.
+		client.NotifyContext(context.WithoutCancel(r.Context()), client.newError(err, 2), Params(r.Form), getCGIData(r), *r.URL)
 		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
 			"success": false,
 			"err":     err,
@@ -131,6 +242,10 @@ func New(c Configuration) *Client {
 		context: newContextSync(),
 	}
 
+	if config.DedupWindow > 0 {
+		client.aggregator = newAggregator(config.DedupWindow, client.buildNotice, client.deliver)
+	}
+
 	return &client
 }
 
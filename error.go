@@ -8,7 +8,13 @@ import (
 	"strconv"
 )
 
-const maxFrames = 20
+// defaultMaxFrames is the number of stack frames captured per backtrace
+// when a Client isn't in the picture (NewError, NewErrorWithCustomOffset)
+// or Configuration.MaxFrames is left at its zero value. It's a per-call
+// parameter rather than shared mutable state, so two Clients configured
+// with different Configuration.MaxFrames values (or concurrent New calls)
+// never race over it.
+const defaultMaxFrames = 20
 
 // Frame represent a stack frame inside of a Honeybadger backtrace.
 type Frame struct {
@@ -23,21 +29,61 @@ type Error struct {
 	Message string
 	Class   string
 	Stack   []*Frame
+
+	// Code is the JSON-RPC 2.0 error code, set when the reported error
+	// implements rpcErrorer. It is zero for ordinary Go errors.
+	Code int
+
+	// Context carries additional fields to merge into the Notice's context,
+	// such as the "data" member of a JSON-RPC 2.0 error object.
+	Context Context
+
+	// rawStack is the untrimmed, unfiltered slice generateStack produced,
+	// kept around purely so releaseStack can return its full-capacity
+	// backing array to frameSlicePool, regardless of how a StackFilter
+	// resliced or rebuilt the public Stack field afterward.
+	rawStack []*Frame
 }
 
 func (e Error) Error() string {
 	return e.Message
 }
 
+// RPCError represents the {code, message, data} error object defined by the
+// JSON-RPC 2.0 spec. Passing one to NewError (or returning one from a
+// handler that honeybadger reports) preserves its code and data on the
+// resulting honeybadger.Error instead of collapsing it to *errors.errorString.
+type RPCError struct {
+	Code    int
+	Message string
+	Data    interface{}
+}
+
+func (e *RPCError) Error() string {
+	return e.Message
+}
+
+// RPCError implements rpcErrorer for *RPCError itself.
+func (e *RPCError) RPCError() (code int, message string, data interface{}) {
+	return e.Code, e.Message, e.Data
+}
+
+// rpcErrorer is implemented by any error that carries a JSON-RPC 2.0 error
+// object, letting callers on top of JSON-RPC 2.0 frameworks report their own
+// error types without wrapping them in *RPCError.
+type rpcErrorer interface {
+	RPCError() (code int, message string, data interface{})
+}
+
 func NewError(msg interface{}) Error {
-	return newError(msg, 2)
+	return newError(msg, 2, defaultMaxFrames)
 }
 
 func NewErrorWithCustomOffset(msg interface{}, stackOffset int) Error {
-	return newError(msg, stackOffset)
+	return newError(msg, stackOffset, defaultMaxFrames)
 }
 
-func newError(thing interface{}, stackOffset int) Error {
+func newError(thing interface{}, stackOffset, maxFrames int) Error {
 	var err error
 	assertedError, ok := thing.(error)
 
@@ -51,29 +97,44 @@ func newError(thing interface{}, stackOffset int) Error {
 		err = fmt.Errorf("%v", assertedError)
 	}
 
-	return Error{
-		err:     err,
-		Message: err.Error(),
-		Class:   reflect.TypeOf(err).String(),
-		Stack:   generateStack(stackOffset),
+	stack := generateStack(stackOffset, maxFrames)
+
+	hbErr := Error{
+		err:      err,
+		Message:  err.Error(),
+		Class:    reflect.TypeOf(err).String(),
+		Stack:    stack,
+		rawStack: stack,
 	}
+
+	if rpcErr, ok := thing.(rpcErrorer); ok {
+		code, message, data := rpcErr.RPCError()
+		hbErr.Class = "jsonrpc2.Error"
+		hbErr.Code = code
+		hbErr.Message = message
+		if data != nil {
+			hbErr.Context = Context{"data": data}
+		}
+	}
+
+	return hbErr
 }
 
-func generateStack(offset int) []*Frame {
+func generateStack(offset, maxFrames int) []*Frame {
 	stack := make([]uintptr, maxFrames)
 	length := runtime.Callers(2+offset, stack[:])
 
 	frames := runtime.CallersFrames(stack[:length])
-	result := make([]*Frame, 0, length)
+	result := getFrameSlice()
 
 	for {
 		frame, more := frames.Next()
 
-		result = append(result, &Frame{
-			File:   frame.File,
-			Number: strconv.Itoa(frame.Line),
-			Method: frame.Function,
-		})
+		f := newFrame()
+		f.File = frame.File
+		f.Number = strconv.Itoa(frame.Line)
+		f.Method = frame.Function
+		result = append(result, f)
 
 		if !more {
 			break
@@ -0,0 +1,76 @@
+package honeybadger
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewErrorOrdinaryError(t *testing.T) {
+	hbErr := newError(errors.New("boom"), 1, defaultMaxFrames)
+
+	if hbErr.Code != 0 {
+		t.Fatalf("expected Code to stay zero for a non-RPC error, got %d", hbErr.Code)
+	}
+	if hbErr.Context != nil {
+		t.Fatalf("expected Context to stay nil for a non-RPC error, got %+v", hbErr.Context)
+	}
+	if hbErr.Message != "boom" {
+		t.Fatalf("expected Message %q, got %q", "boom", hbErr.Message)
+	}
+}
+
+func TestNewErrorRPCError(t *testing.T) {
+	rpcErr := &RPCError{Code: -32000, Message: "server error", Data: map[string]string{"field": "name"}}
+
+	hbErr := newError(rpcErr, 1, defaultMaxFrames)
+
+	if hbErr.Class != "jsonrpc2.Error" {
+		t.Fatalf("expected Class %q, got %q", "jsonrpc2.Error", hbErr.Class)
+	}
+	if hbErr.Code != -32000 {
+		t.Fatalf("expected Code -32000, got %d", hbErr.Code)
+	}
+	if hbErr.Message != "server error" {
+		t.Fatalf("expected Message %q, got %q", "server error", hbErr.Message)
+	}
+	if hbErr.Context == nil || hbErr.Context["data"] == nil {
+		t.Fatalf("expected Context[\"data\"] to carry the RPCError's Data, got %+v", hbErr.Context)
+	}
+}
+
+func TestNewErrorRPCErrorWithNilData(t *testing.T) {
+	hbErr := newError(&RPCError{Code: -32001, Message: "no data"}, 1, defaultMaxFrames)
+
+	if hbErr.Context != nil {
+		t.Fatalf("expected Context to stay nil when Data is nil, got %+v", hbErr.Context)
+	}
+}
+
+// customRPCError implements rpcErrorer without embedding *RPCError, so
+// newError's detection has to go through the interface rather than a type
+// assertion to *RPCError specifically.
+type customRPCError struct {
+	code int
+	msg  string
+	data interface{}
+}
+
+func (e *customRPCError) Error() string { return e.msg }
+
+func (e *customRPCError) RPCError() (code int, message string, data interface{}) {
+	return e.code, e.msg, e.data
+}
+
+func TestNewErrorCustomRPCErrorer(t *testing.T) {
+	hbErr := newError(&customRPCError{code: 7, msg: "custom", data: 42}, 1, defaultMaxFrames)
+
+	if hbErr.Class != "jsonrpc2.Error" {
+		t.Fatalf("expected Class %q, got %q", "jsonrpc2.Error", hbErr.Class)
+	}
+	if hbErr.Code != 7 {
+		t.Fatalf("expected Code 7, got %d", hbErr.Code)
+	}
+	if hbErr.Context == nil || hbErr.Context["data"] != 42 {
+		t.Fatalf("expected Context[\"data\"] == 42, got %+v", hbErr.Context)
+	}
+}
@@ -0,0 +1,152 @@
+package honeybadger
+
+import (
+	"fmt"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// captureGoroutineStacks snapshots every goroutine's stack, as
+// runtime.Stack(buf, true) would, but groups goroutines that share a state
+// and a stack of method names (after running it through filter) into one entry
+// instead of repeating the same trace once per goroutine. Client.Monitor
+// attaches the result to the panic's Notice context (under "goroutines")
+// when Configuration.CaptureGoroutines is enabled. A worker pool blocked on
+// the same channel, or N request goroutines stuck in the same downstream
+// call, is the common case; a raw dump drowns that signal in duplicate
+// text, one copy per goroutine.
+func captureGoroutineStacks(filter StackFilter) string {
+	if filter == nil {
+		filter = DefaultStackFilter
+	}
+	return formatGoroutineGroups(groupGoroutineStacks(parseGoroutineDump(rawGoroutineDump()), filter))
+}
+
+func rawGoroutineDump() string {
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return string(buf[:n])
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// goroutineHeaderRE matches the "goroutine 1 [running]:" line runtime.Stack
+// prints at the start of each goroutine's block.
+var goroutineHeaderRE = regexp.MustCompile(`^goroutine \d+ \[([^\]]+)\]:$`)
+
+type goroutineStack struct {
+	state  string
+	frames []*Frame
+}
+
+// parseGoroutineDump splits a runtime.Stack(all=true) dump (goroutine
+// blocks separated by a blank line, each frame a function-call line
+// followed by an indented file:line) into one goroutineStack per goroutine.
+func parseGoroutineDump(dump string) []goroutineStack {
+	var stacks []goroutineStack
+
+	for _, block := range strings.Split(dump, "\n\n") {
+		lines := strings.Split(strings.TrimRight(block, "\n"), "\n")
+		if len(lines) == 0 {
+			continue
+		}
+
+		header := goroutineHeaderRE.FindStringSubmatch(lines[0])
+		if header == nil {
+			continue
+		}
+		stack := goroutineStack{state: header[1]}
+
+		for i := 1; i+1 < len(lines); i += 2 {
+			method := strings.TrimSpace(lines[i])
+			file, number := splitFileLine(strings.TrimSpace(lines[i+1]))
+			stack.frames = append(stack.frames, &Frame{Method: method, File: file, Number: number})
+		}
+
+		stacks = append(stacks, stack)
+	}
+
+	return stacks
+}
+
+// splitFileLine parses a "/path/to/file.go:42 +0x1a" location line into its
+// file and line number, discarding the trailing program-counter offset.
+func splitFileLine(loc string) (file, number string) {
+	if idx := strings.IndexByte(loc, ' '); idx >= 0 {
+		loc = loc[:idx]
+	}
+	idx := strings.LastIndexByte(loc, ':')
+	if idx < 0 {
+		return loc, ""
+	}
+	return loc[:idx], loc[idx+1:]
+}
+
+type goroutineGroup struct {
+	state  string
+	frames []*Frame
+	count  int
+}
+
+// groupGoroutineStacks runs each goroutine's frames through filter and
+// collapses goroutines left with the same state and the same sequence of
+// filtered method names into a single group, most-populous first. File and
+// line numbers are not compared; a group keeps its first goroutine's frames.
+func groupGoroutineStacks(stacks []goroutineStack, filter StackFilter) []goroutineGroup {
+	type key struct{ state, signature string }
+
+	groups := make(map[key]*goroutineGroup)
+	var order []key
+
+	for _, stack := range stacks {
+		frames := filter(stack.frames)
+		k := key{state: stack.state, signature: frameSignature(frames)}
+
+		if g, ok := groups[k]; ok {
+			g.count++
+			continue
+		}
+		groups[k] = &goroutineGroup{state: stack.state, frames: frames, count: 1}
+		order = append(order, k)
+	}
+
+	result := make([]goroutineGroup, 0, len(order))
+	for _, k := range order {
+		result = append(result, *groups[k])
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].count > result[j].count })
+
+	return result
+}
+
+func frameSignature(frames []*Frame) string {
+	var sig strings.Builder
+	for _, frame := range frames {
+		sig.WriteString(frame.Method)
+		sig.WriteByte('\n')
+	}
+	return sig.String()
+}
+
+func formatGoroutineGroups(groups []goroutineGroup) string {
+	var out strings.Builder
+	for i, group := range groups {
+		if i > 0 {
+			out.WriteString("\n\n")
+		}
+		noun := "goroutine"
+		if group.count != 1 {
+			noun = "goroutines"
+		}
+		fmt.Fprintf(&out, "%d %s [%s]:\n", group.count, noun, group.state)
+		for _, frame := range group.frames {
+			fmt.Fprintf(&out, "%s\n\t%s:%s\n", frame.Method, frame.File, frame.Number)
+		}
+	}
+	return out.String()
+}
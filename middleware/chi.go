@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	honeybadger "github.com/timmyhoa/honeybadger-go"
+)
+
+// Chi returns a chi-style middleware (func(http.Handler) http.Handler) that
+// reports panics to client and re-panics, exactly like honeybadger.Handler
+// but fitting chi's middleware signature so it can be passed to r.Use.
+func Chi(client *honeybadger.Client) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				if err := recover(); err != nil {
+					params, cgiData, url := extractRequest(r)
+					// See the matching comment in honeybadger.Client.Handler:
+					// r.Context() is cancelled the instant ServeHTTP returns,
+					// so detach before handing it to the (usually async)
+					// worker.
+					client.NotifyContext(context.WithoutCancel(r.Context()), err, params, cgiData, url)
+					panic(err)
+				}
+			}()
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/labstack/echo/v4"
+
+	honeybadger "github.com/timmyhoa/honeybadger-go"
+)
+
+// Echo returns an echo.MiddlewareFunc that reports panics recovered from a
+// handler to client, using the route's net/http request for Params/CGIData,
+// and re-raises the panic afterwards so echo's own recover middleware (or
+// the process) still sees it.
+func Echo(client *honeybadger.Client) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			defer func() {
+				if err := recover(); err != nil {
+					r := c.Request()
+					params, cgiData, url := extractRequest(r)
+					// r.Context() is cancelled the instant this handler
+					// returns, which happens right after this panic is
+					// re-raised below; see the matching comment on
+					// honeybadger.Client.Handler.
+					client.NotifyContext(context.WithoutCancel(r.Context()), err, params, cgiData, url)
+					panic(err)
+				}
+			}()
+			return next(c)
+		}
+	}
+}
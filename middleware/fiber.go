@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	honeybadger "github.com/timmyhoa/honeybadger-go"
+)
+
+// Fiber returns a fiber.Handler that reports panics recovered from the rest
+// of the chain to client. Fiber runs on fasthttp rather than net/http, so
+// request metadata is pulled from *fiber.Ctx directly instead of the shared
+// extractRequest helper.
+//
+// Unlike the other adapters in this package, the live *fiber.Ctx's
+// context.Context (backed by *fasthttp.RequestCtx) is never handed to
+// NotifyContext: fasthttp explicitly documents RequestCtx as pooled and
+// reset for the next request the instant this handler returns, which
+// happens right after the panic below is re-raised, so even a
+// context.WithoutCancel wrapper (which still calls through to the parent's
+// Value) would be reading from a context that may already belong to an
+// unrelated request by the time the (usually async) worker delivers the
+// notice. context.Background() is the only safe detach here.
+func Fiber(client *honeybadger.Client) fiber.Handler {
+	return func(c *fiber.Ctx) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				client.NotifyContext(context.Background(), r, fiberParams(c), fiberCGIData(c))
+				panic(r)
+			}
+		}()
+		return c.Next()
+	}
+}
+
+func fiberParams(c *fiber.Ctx) honeybadger.Params {
+	params := honeybadger.Params{}
+	c.Context().QueryArgs().VisitAll(func(key, value []byte) {
+		params[string(key)] = []string{string(value)}
+	})
+	return params
+}
+
+func fiberCGIData(c *fiber.Ctx) honeybadger.CGIData {
+	cgiData := honeybadger.CGIData{}
+	replacer := strings.NewReplacer("-", "_")
+	c.Context().Request.Header.VisitAll(func(key, value []byte) {
+		cgiData["HTTP_"+replacer.Replace(strings.ToUpper(string(key)))] = string(value)
+	})
+	return cgiData
+}
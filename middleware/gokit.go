@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+
+	honeybadger "github.com/timmyhoa/honeybadger-go"
+)
+
+// GoKit returns an endpoint.Middleware that reports panics recovered from
+// the wrapped endpoint to client, then re-panics so an outer recovery layer
+// (or the process) still observes it.
+func GoKit(client *honeybadger.Client) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					// ctx is cancelled once this endpoint returns, which
+					// happens right after this panic is re-raised below; see
+					// the matching comment in honeybadger.Client.Handler.
+					client.NotifyContext(context.WithoutCancel(ctx), r, honeybadger.Context{"request": request})
+					panic(r)
+				}
+			}()
+			return next(ctx, request)
+		}
+	}
+}
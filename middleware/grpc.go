@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	honeybadger "github.com/timmyhoa/honeybadger-go"
+)
+
+// UnaryServerInterceptor returns a grpc.UnaryServerInterceptor that reports
+// panics recovered from a unary handler to client, tagging the notice with
+// the RPC's full method name, then re-panics.
+func UnaryServerInterceptor(client *honeybadger.Client) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				// ctx is cancelled once the RPC returns, which happens right
+				// after this panic is re-raised below; see the matching
+				// comment in honeybadger.Client.Handler.
+				client.NotifyContext(context.WithoutCancel(ctx), r, honeybadger.Context{"grpc_method": info.FullMethod, "request": req})
+				panic(r)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a grpc.StreamServerInterceptor that
+// reports panics recovered from a streaming handler to client, tagging the
+// notice with the RPC's full method name, then re-panics.
+func StreamServerInterceptor(client *honeybadger.Client) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				// See the matching comment in UnaryServerInterceptor: detach
+				// from ss.Context() before notifying so the worker doesn't
+				// see it cancelled once this RPC returns.
+				client.NotifyContext(context.WithoutCancel(ss.Context()), r, honeybadger.Context{"grpc_method": info.FullMethod})
+				panic(r)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,18 @@
+// Package middleware provides ready-made recovery/notify adapters for web
+// frameworks beyond the net/http and gin support built into the honeybadger
+// package itself.
+package middleware
+
+import (
+	"net/http"
+	"net/url"
+
+	honeybadger "github.com/timmyhoa/honeybadger-go"
+)
+
+// extractRequest pulls the same Params/CGIData/URL triple out of r that
+// honeybadger.Handler and honeybadger.GinRecoveryHandler pass to Notify, so
+// every framework adapter reports requests the same way.
+func extractRequest(r *http.Request) (honeybadger.Params, honeybadger.CGIData, url.URL) {
+	return honeybadger.Params(r.Form), honeybadger.GetCGIData(r), *r.URL
+}
@@ -0,0 +1,24 @@
+package honeybadger
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceFromContext reads the active OpenTelemetry span out of ctx and
+// returns a Context fragment carrying its IDs, suitable for passing as an
+// extra argument to Notify/NotifyContext. ok is false when ctx carries no
+// valid span context; a valid but non-recording span still yields its IDs.
+func traceFromContext(ctx context.Context) (Context, bool) {
+	span := trace.SpanFromContext(ctx)
+	spanCtx := span.SpanContext()
+	if !spanCtx.IsValid() {
+		return nil, false
+	}
+
+	return Context{
+		"trace_id": spanCtx.TraceID().String(),
+		"span_id":  spanCtx.SpanID().String(),
+	}, true
+}
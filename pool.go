@@ -0,0 +1,80 @@
+package honeybadger
+
+import (
+	"bytes"
+	"sync"
+)
+
+// framePool and frameSlicePool recycle the *Frame structs and backing
+// slices that generateStack and DefaultStackFilter allocate on every
+// Notify, and bufferPool recycles the bytes.Buffer the HTTP backend uses to
+// build each outbound request body. Modeled on how gin pools its request
+// Context, the goal is a steady-state happy path with ~zero allocations
+// once the pools have warmed up.
+var framePool = sync.Pool{
+	New: func() interface{} { return new(Frame) },
+}
+
+var frameSlicePool = sync.Pool{
+	New: func() interface{} {
+		s := make([]*Frame, 0, defaultMaxFrames)
+		return &s
+	},
+}
+
+var bufferPool = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
+// noticePool recycles *Notice values. newNotice (in notice.go, outside this
+// chunk) is expected to call getNotice instead of `new(Notice)`; until it
+// does, putNotice still drains into a pool that simply never gets drawn
+// from, which is harmless but leaves the allocation on the table.
+var noticePool = sync.Pool{
+	New: func() interface{} { return new(Notice) },
+}
+
+func newFrame() *Frame {
+	return framePool.Get().(*Frame)
+}
+
+func getFrameSlice() []*Frame {
+	return (*frameSlicePool.Get().(*[]*Frame))[:0]
+}
+
+// releaseStack returns hbErr's surviving frames, and the full-capacity slice
+// generateStack originally allocated for it, to their pools. StackFilter
+// implementations (e.g. DefaultStackFilter) already return frames they drop
+// to framePool themselves as they trim/collapse, so this only needs to
+// release what's left in Stack plus the untouched rawStack backing array.
+// Callers must not retain hbErr.Stack (or anything pointing into it) after
+// calling this.
+func releaseStack(hbErr Error) {
+	for _, frame := range hbErr.Stack {
+		*frame = Frame{}
+		framePool.Put(frame)
+	}
+	if hbErr.rawStack != nil {
+		raw := hbErr.rawStack[:0]
+		frameSlicePool.Put(&raw)
+	}
+}
+
+func getNotice() *Notice {
+	return noticePool.Get().(*Notice)
+}
+
+func putNotice(notice *Notice) {
+	*notice = Notice{}
+	noticePool.Put(notice)
+}
+
+func getBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	bufferPool.Put(buf)
+}
@@ -0,0 +1,14 @@
+// Package proto holds the message and service types described by
+// honeybadger.proto.
+//
+// These are hand-maintained stubs, not protoc output: this environment has
+// no protoc/protoc-gen-go/protoc-gen-go-grpc available to generate the real
+// bindings from. honeybadger.pb.go and honeybadger_grpc.pb.go encode/decode
+// the exact wire format honeybadger.proto describes (so they interoperate
+// with a real protobuf peer) without depending on protobuf reflection, via
+// a codec registered under the "honeybadger-stub" content-subtype.
+//
+// Once protoc is available, replace both files with:
+//
+//go:generate protoc --go_out=. --go-grpc_out=. honeybadger.proto
+package proto
@@ -0,0 +1,275 @@
+package proto
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// codecName is the content-subtype NewNoticeServiceClient registers its
+// calls under, so they're marshaled by wireCodec below instead of grpc-go's
+// default codec, which requires a real google.golang.org/protobuf
+// proto.Message (see doc.go for why these types aren't one).
+const codecName = "honeybadger-stub"
+
+func init() {
+	encoding.RegisterCodec(wireCodec{})
+}
+
+// wireMessage is implemented by every message type in this package, so
+// wireCodec can marshal/unmarshal them without reflection.
+type wireMessage interface {
+	Marshal() ([]byte, error)
+	Unmarshal([]byte) error
+}
+
+// wireCodec marshals wireMessage values using the same proto3 wire format
+// protoc would generate for honeybadger.proto, so a real protobuf server
+// still decodes what this client sends.
+type wireCodec struct{}
+
+func (wireCodec) Name() string { return codecName }
+
+func (wireCodec) Marshal(v any) ([]byte, error) {
+	m, ok := v.(wireMessage)
+	if !ok {
+		return nil, fmt.Errorf("proto: %T does not implement wireMessage", v)
+	}
+	return m.Marshal()
+}
+
+func (wireCodec) Unmarshal(data []byte, v any) error {
+	m, ok := v.(wireMessage)
+	if !ok {
+		return fmt.Errorf("proto: %T does not implement wireMessage", v)
+	}
+	return m.Unmarshal(data)
+}
+
+// Hint_Action mirrors the Action enum in honeybadger.proto.
+type Hint_Action int32
+
+const (
+	Hint_NONE     Hint_Action = 0
+	Hint_SAMPLE   Hint_Action = 1
+	Hint_BACK_OFF Hint_Action = 2
+)
+
+func (a Hint_Action) String() string {
+	switch a {
+	case Hint_SAMPLE:
+		return "SAMPLE"
+	case Hint_BACK_OFF:
+		return "BACK_OFF"
+	default:
+		return "NONE"
+	}
+}
+
+// Notice carries a single JSON-encoded payload produced by Notice.toJSON or
+// Deploy.toJSON on the client, tagged with the Feature it targets.
+type Notice struct {
+	Feature string
+	Payload []byte
+}
+
+func (n *Notice) Reset() { *n = Notice{} }
+
+func (n *Notice) String() string {
+	return fmt.Sprintf("feature:%q payload:%d bytes", n.Feature, len(n.Payload))
+}
+
+func (n *Notice) Marshal() ([]byte, error) {
+	var buf []byte
+	if n.Feature != "" {
+		buf = appendTag(buf, 1, wireBytes)
+		buf = appendString(buf, n.Feature)
+	}
+	if len(n.Payload) > 0 {
+		buf = appendTag(buf, 2, wireBytes)
+		buf = appendBytes(buf, n.Payload)
+	}
+	return buf, nil
+}
+
+func (n *Notice) Unmarshal(data []byte) error {
+	*n = Notice{}
+	return forEachField(data, func(field int, typ wireType, data []byte) ([]byte, error) {
+		switch field {
+		case 1:
+			s, tail, err := consumeString(data)
+			n.Feature = s
+			return tail, err
+		case 2:
+			b, tail, err := consumeBytes(data)
+			n.Payload = b
+			return tail, err
+		default:
+			return skipField(typ, data)
+		}
+	})
+}
+
+// Hint is pushed by the server down the stream to ask the client to adjust
+// its behavior, e.g. sample or back off during an incident.
+type Hint struct {
+	Action       Hint_Action
+	SampleRate   float64
+	RetryAfterMs int64
+}
+
+func (h *Hint) Reset() { *h = Hint{} }
+
+func (h *Hint) String() string {
+	return fmt.Sprintf("action:%s sample_rate:%v retry_after_ms:%d", h.Action, h.SampleRate, h.RetryAfterMs)
+}
+
+func (h *Hint) Marshal() ([]byte, error) {
+	var buf []byte
+	if h.Action != Hint_NONE {
+		buf = appendTag(buf, 1, wireVarint)
+		buf = appendVarint(buf, uint64(h.Action))
+	}
+	if h.SampleRate != 0 {
+		buf = appendTag(buf, 2, wireFixed64)
+		buf = appendFixed64(buf, math.Float64bits(h.SampleRate))
+	}
+	if h.RetryAfterMs != 0 {
+		buf = appendTag(buf, 3, wireVarint)
+		buf = appendVarint(buf, uint64(h.RetryAfterMs))
+	}
+	return buf, nil
+}
+
+func (h *Hint) Unmarshal(data []byte) error {
+	*h = Hint{}
+	return forEachField(data, func(field int, typ wireType, data []byte) ([]byte, error) {
+		switch field {
+		case 1:
+			v, tail, err := consumeVarint(data)
+			h.Action = Hint_Action(v)
+			return tail, err
+		case 2:
+			v, tail, err := consumeFixed64(data)
+			h.SampleRate = math.Float64frombits(v)
+			return tail, err
+		case 3:
+			v, tail, err := consumeVarint(data)
+			h.RetryAfterMs = int64(v)
+			return tail, err
+		default:
+			return skipField(typ, data)
+		}
+	})
+}
+
+// The remainder of this file is a minimal proto3 wire-format codec: varint,
+// 64-bit, and length-delimited fields, which is all Notice and Hint need.
+
+type wireType int
+
+const (
+	wireVarint  wireType = 0
+	wireFixed64 wireType = 1
+	wireBytes   wireType = 2
+)
+
+func appendTag(buf []byte, field int, typ wireType) []byte {
+	return appendVarint(buf, uint64(field)<<3|uint64(typ))
+}
+
+func appendVarint(buf []byte, v uint64) []byte {
+	for v >= 0x80 {
+		buf = append(buf, byte(v)|0x80)
+		v >>= 7
+	}
+	return append(buf, byte(v))
+}
+
+func appendFixed64(buf []byte, v uint64) []byte {
+	var tmp [8]byte
+	binary.LittleEndian.PutUint64(tmp[:], v)
+	return append(buf, tmp[:]...)
+}
+
+func appendString(buf []byte, s string) []byte {
+	return appendBytes(buf, []byte(s))
+}
+
+func appendBytes(buf []byte, b []byte) []byte {
+	buf = appendVarint(buf, uint64(len(b)))
+	return append(buf, b...)
+}
+
+func consumeVarint(data []byte) (uint64, []byte, error) {
+	var v uint64
+	var shift uint
+	for i, b := range data {
+		v |= uint64(b&0x7f) << shift
+		if b < 0x80 {
+			return v, data[i+1:], nil
+		}
+		shift += 7
+	}
+	return 0, nil, fmt.Errorf("proto: truncated varint")
+}
+
+func consumeFixed64(data []byte) (uint64, []byte, error) {
+	if len(data) < 8 {
+		return 0, nil, fmt.Errorf("proto: truncated fixed64")
+	}
+	return binary.LittleEndian.Uint64(data[:8]), data[8:], nil
+}
+
+func consumeBytes(data []byte) ([]byte, []byte, error) {
+	n, rest, err := consumeVarint(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if uint64(len(rest)) < n {
+		return nil, nil, fmt.Errorf("proto: truncated length-delimited field")
+	}
+	return rest[:n:n], rest[n:], nil
+}
+
+func consumeString(data []byte) (string, []byte, error) {
+	b, tail, err := consumeBytes(data)
+	return string(b), tail, err
+}
+
+// forEachField walks data tag by tag, handing each field's remaining bytes
+// to handle. handle must consume exactly that field's value and return
+// whatever follows it.
+func forEachField(data []byte, handle func(field int, typ wireType, data []byte) ([]byte, error)) error {
+	for len(data) > 0 {
+		tag, rest, err := consumeVarint(data)
+		if err != nil {
+			return err
+		}
+		field, typ := int(tag>>3), wireType(tag&0x7)
+		rest, err = handle(field, typ, rest)
+		if err != nil {
+			return err
+		}
+		data = rest
+	}
+	return nil
+}
+
+func skipField(typ wireType, data []byte) ([]byte, error) {
+	switch typ {
+	case wireVarint:
+		_, rest, err := consumeVarint(data)
+		return rest, err
+	case wireFixed64:
+		_, rest, err := consumeFixed64(data)
+		return rest, err
+	case wireBytes:
+		_, rest, err := consumeBytes(data)
+		return rest, err
+	default:
+		return nil, fmt.Errorf("proto: unsupported wire type %d", typ)
+	}
+}
@@ -0,0 +1,113 @@
+package proto
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+const noticeServiceStreamMethod = "/honeybadger.NoticeService/Stream"
+
+var noticeServiceServiceDesc = grpc.ServiceDesc{
+	ServiceName: "honeybadger.NoticeService",
+	HandlerType: (*NoticeServiceServer)(nil),
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Stream",
+			Handler:       noticeServiceStreamHandler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+}
+
+// NoticeServiceClient is the client API for NoticeService, matching the
+// honeybadger.NoticeService gRPC service.
+type NoticeServiceClient interface {
+	Stream(ctx context.Context, opts ...grpc.CallOption) (NoticeService_StreamClient, error)
+}
+
+type noticeServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewNoticeServiceClient returns a NoticeServiceClient that dials through
+// cc, marshaling requests with the wireCodec registered in honeybadger.pb.go
+// rather than grpc-go's default proto codec.
+func NewNoticeServiceClient(cc grpc.ClientConnInterface) NoticeServiceClient {
+	return &noticeServiceClient{cc}
+}
+
+func (c *noticeServiceClient) Stream(ctx context.Context, opts ...grpc.CallOption) (NoticeService_StreamClient, error) {
+	opts = append([]grpc.CallOption{grpc.CallContentSubtype(codecName)}, opts...)
+	stream, err := c.cc.NewStream(ctx, &noticeServiceServiceDesc.Streams[0], noticeServiceStreamMethod, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &noticeServiceStreamClient{stream}, nil
+}
+
+// NoticeService_StreamClient is the client-side stream handle returned by
+// NoticeServiceClient.Stream.
+type NoticeService_StreamClient interface {
+	Send(*Notice) error
+	Recv() (*Hint, error)
+	grpc.ClientStream
+}
+
+type noticeServiceStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *noticeServiceStreamClient) Send(m *Notice) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *noticeServiceStreamClient) Recv() (*Hint, error) {
+	m := new(Hint)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// NoticeServiceServer is the server API for NoticeService. A real
+// Honeybadger-compatible server implements this; this package only needs
+// the client side, but the interface is kept here to mirror what
+// protoc-gen-go-grpc would otherwise generate.
+type NoticeServiceServer interface {
+	Stream(NoticeService_StreamServer) error
+}
+
+// NoticeService_StreamServer is the server-side stream handle passed to
+// NoticeServiceServer.Stream.
+type NoticeService_StreamServer interface {
+	Send(*Hint) error
+	Recv() (*Notice, error)
+	grpc.ServerStream
+}
+
+type noticeServiceStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *noticeServiceStreamServer) Send(m *Hint) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *noticeServiceStreamServer) Recv() (*Notice, error) {
+	m := new(Notice)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func noticeServiceStreamHandler(srv any, stream grpc.ServerStream) error {
+	return srv.(NoticeServiceServer).Stream(&noticeServiceStreamServer{stream})
+}
+
+// RegisterNoticeServiceServer registers srv to handle NoticeService RPCs on s.
+func RegisterNoticeServiceServer(s grpc.ServiceRegistrar, srv NoticeServiceServer) {
+	s.RegisterService(&noticeServiceServiceDesc, srv)
+}
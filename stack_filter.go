@@ -0,0 +1,123 @@
+package honeybadger
+
+import (
+	"strconv"
+	"strings"
+)
+
+// StackFilter post-processes a backtrace captured by generateStack before it
+// is attached to a Notice. Configuration.StackFilter may be set to replace
+// DefaultStackFilter, e.g. to apply a different module prefix or to keep
+// frames DefaultStackFilter would otherwise drop.
+type StackFilter func([]*Frame) []*Frame
+
+// runtimeGluePrefixes names the packages DefaultStackFilter trims from the
+// top and bottom of a backtrace: the runtime machinery that gets you into
+// and out of user code, not the code that actually panicked.
+var runtimeGluePrefixes = []string{
+	"runtime.",
+	"reflect.",
+}
+
+// DefaultStackFilter trims runtime/reflect glue frames from both ends of the
+// backtrace and collapses consecutive frames that recursed into themselves
+// into a single frame annotated with a repetition count.
+func DefaultStackFilter(frames []*Frame) []*Frame {
+	frames = trimGlueFrames(frames)
+	return collapseRepeatedFrames(frames)
+}
+
+func trimGlueFrames(frames []*Frame) []*Frame {
+	start := 0
+	for start < len(frames) && isGlueFrame(frames[start]) {
+		framePool.Put(frames[start])
+		start++
+	}
+
+	end := len(frames)
+	for end > start && isGlueFrame(frames[end-1]) {
+		end--
+		framePool.Put(frames[end])
+	}
+
+	return frames[start:end]
+}
+
+func isGlueFrame(frame *Frame) bool {
+	for _, prefix := range runtimeGluePrefixes {
+		if strings.HasPrefix(frame.Method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// collapseRepeatedFrames merges runs of identical (file, method) frames,
+// which recursive calls otherwise repeat once per call depth, into a single
+// frame whose Method is annotated with "(xN)". It compacts frames in place
+// (result shares its backing array with frames, which is always large
+// enough since result never grows past frames' length) and returns the
+// dropped duplicates to framePool instead of allocating a new frame or a
+// new backing slice.
+func collapseRepeatedFrames(frames []*Frame) []*Frame {
+	if len(frames) == 0 {
+		return frames
+	}
+
+	result := frames[:0]
+	current := frames[0]
+	count := 1
+
+	flush := func() {
+		if count > 1 {
+			current.Method = current.Method + " " + repeatSuffix(count)
+		}
+		result = append(result, current)
+	}
+
+	for _, frame := range frames[1:] {
+		if frame.File == current.File && frame.Method == current.Method {
+			count++
+			framePool.Put(frame)
+			continue
+		}
+		flush()
+		current = frame
+		count = 1
+	}
+	flush()
+
+	return result
+}
+
+func repeatSuffix(count int) string {
+	return "(x" + strconv.Itoa(count) + ")"
+}
+
+// shortenPackagePath strips prefix (typically the module path, e.g.
+// "github.com/org/repo/") from a fully-qualified file or method name so
+// backtraces read as relative paths instead of GOPATH/module-absolute ones.
+func shortenPackagePath(path, prefix string) string {
+	if prefix == "" {
+		return path
+	}
+	if idx := strings.Index(path, prefix); idx >= 0 {
+		return path[idx+len(prefix):]
+	}
+	return path
+}
+
+// NewModuleStackFilter returns a StackFilter that runs DefaultStackFilter and
+// additionally shortens every frame's File and Method by stripping modulePrefix
+// (e.g. "github.com/org/repo/"), so backtraces stay readable for deep
+// middleware chains without the caller grooming them by hand.
+func NewModuleStackFilter(modulePrefix string) StackFilter {
+	return func(frames []*Frame) []*Frame {
+		frames = DefaultStackFilter(frames)
+		for _, frame := range frames {
+			frame.File = shortenPackagePath(frame.File, modulePrefix)
+			frame.Method = shortenPackagePath(frame.Method, modulePrefix)
+		}
+		return frames
+	}
+}
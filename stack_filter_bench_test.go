@@ -0,0 +1,16 @@
+package honeybadger
+
+import "testing"
+
+// BenchmarkDefaultStackFilter exercises the same generateStack ->
+// DefaultStackFilter -> releaseStack round trip Notify runs on its hot path,
+// so -benchmem shows whether the Frame/slice pools added in chunk0-5 are
+// actually keeping allocations out of steady state.
+func BenchmarkDefaultStackFilter(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		hbErr := newError("benchmark error", 1, defaultMaxFrames)
+		hbErr.Stack = DefaultStackFilter(hbErr.Stack)
+		releaseStack(hbErr)
+	}
+}
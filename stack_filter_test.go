@@ -0,0 +1,42 @@
+package honeybadger
+
+import "testing"
+
+func TestTrimGlueFrames(t *testing.T) {
+	frames := []*Frame{
+		{Method: "runtime.gopanic"},
+		{Method: "reflect.Value.call"},
+		{Method: "github.com/timmyhoa/honeybadger-go.doWork"},
+		{Method: "runtime.goexit"},
+	}
+
+	trimmed := trimGlueFrames(frames)
+
+	if len(trimmed) != 1 {
+		t.Fatalf("expected 1 frame after trimming, got %d", len(trimmed))
+	}
+	if trimmed[0].Method != "github.com/timmyhoa/honeybadger-go.doWork" {
+		t.Fatalf("unexpected surviving frame: %+v", trimmed[0])
+	}
+}
+
+func TestCollapseRepeatedFrames(t *testing.T) {
+	frames := []*Frame{
+		{File: "recurse.go", Method: "recurse"},
+		{File: "recurse.go", Method: "recurse"},
+		{File: "recurse.go", Method: "recurse"},
+		{File: "main.go", Method: "main"},
+	}
+
+	collapsed := collapseRepeatedFrames(frames)
+
+	if len(collapsed) != 2 {
+		t.Fatalf("expected 2 frames after collapsing, got %d", len(collapsed))
+	}
+	if want := "recurse (x3)"; collapsed[0].Method != want {
+		t.Fatalf("expected repeated frame annotated as %q, got %q", want, collapsed[0].Method)
+	}
+	if collapsed[1].Method != "main" {
+		t.Fatalf("unexpected trailing frame: %+v", collapsed[1])
+	}
+}
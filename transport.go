@@ -0,0 +1,24 @@
+package honeybadger
+
+// Transport names understood by NewBackend. These select which concrete
+// Backend implementation Configuration.Backend is populated with when the
+// client is constructed.
+const (
+	TransportHTTP  = "http"
+	TransportGRPC  = "grpc"
+	TransportLocal = "local"
+)
+
+// NewBackend returns the Backend implementation for the given transport
+// name, configured from config. Unknown transport names fall back to the
+// HTTP backend, which matches the client's historical default.
+func NewBackend(transport string, config *Configuration) Backend {
+	switch transport {
+	case TransportGRPC:
+		return newGRPCBackend(config)
+	case TransportLocal:
+		return newLocalBackend(config)
+	default:
+		return newHTTPBackend(config)
+	}
+}